@@ -0,0 +1,65 @@
+package commatrix
+
+func init() {
+	RegisterProvider(AWS, staticProvider{
+		name:   "aws",
+		master: awsCloudStaticEntriesMaster,
+		worker: awsCloudStaticEntriesWorker,
+	})
+}
+
+// awsCloudStaticEntriesMaster covers the AWS-specific flows master nodes
+// need: the instance metadata service and the AWS cloud-controller-manager.
+const awsCloudStaticEntriesMaster = `
+[
+    {
+        "direction": "Egress",
+        "protocol": "TCP",
+        "port": 80,
+        "namespace": "",
+        "service": "aws-imds",
+        "pod": "",
+        "container": "",
+        "nodeRole": "master",
+        "optional": false
+    },
+    {
+        "direction": "Ingress",
+        "protocol": "TCP",
+        "port": 10258,
+        "namespace": "openshift-cloud-controller-manager",
+        "service": "aws-cloud-controller-manager",
+        "pod": "aws-cloud-controller-manager",
+        "container": "cloud-controller-manager",
+        "nodeRole": "master",
+        "optional": false
+    }
+]`
+
+// awsCloudStaticEntriesWorker covers the AWS ELB health-check traffic that
+// reaches worker nodes behind the classic/network load balancer.
+const awsCloudStaticEntriesWorker = `
+[
+    {
+        "direction": "Egress",
+        "protocol": "TCP",
+        "port": 80,
+        "namespace": "",
+        "service": "aws-imds",
+        "pod": "",
+        "container": "",
+        "nodeRole": "worker",
+        "optional": false
+    },
+    {
+        "direction": "Ingress",
+        "protocol": "TCP",
+        "port": 30256,
+        "namespace": "",
+        "service": "aws-elb-health-check",
+        "pod": "",
+        "container": "",
+        "nodeRole": "worker",
+        "optional": true
+    }
+]`
@@ -0,0 +1,76 @@
+package commatrix
+
+func init() {
+	RegisterProvider(Azure, staticProvider{
+		name:   "azure",
+		master: azureStaticEntriesMaster,
+		worker: azureStaticEntriesWorker,
+	})
+}
+
+// azureStaticEntriesMaster covers the Azure-specific flows master nodes
+// need: the WireServer (168.63.129.16) used for host/guest communication,
+// the instance metadata service, and the Azure cloud-controller-manager.
+const azureStaticEntriesMaster = `
+[
+    {
+        "direction": "Egress",
+        "protocol": "TCP",
+        "port": 32526,
+        "namespace": "",
+        "service": "azure-wireserver",
+        "pod": "",
+        "container": "",
+        "nodeRole": "master",
+        "optional": false
+    },
+    {
+        "direction": "Egress",
+        "protocol": "TCP",
+        "port": 80,
+        "namespace": "",
+        "service": "azure-imds",
+        "pod": "",
+        "container": "",
+        "nodeRole": "master",
+        "optional": false
+    },
+    {
+        "direction": "Ingress",
+        "protocol": "TCP",
+        "port": 10262,
+        "namespace": "openshift-cloud-controller-manager",
+        "service": "azure-cloud-controller-manager",
+        "pod": "azure-cloud-controller-manager",
+        "container": "cloud-controller-manager",
+        "nodeRole": "master",
+        "optional": false
+    }
+]`
+
+// azureStaticEntriesWorker covers the Azure-specific flows worker nodes need.
+const azureStaticEntriesWorker = `
+[
+    {
+        "direction": "Egress",
+        "protocol": "TCP",
+        "port": 32526,
+        "namespace": "",
+        "service": "azure-wireserver",
+        "pod": "",
+        "container": "",
+        "nodeRole": "worker",
+        "optional": false
+    },
+    {
+        "direction": "Egress",
+        "protocol": "TCP",
+        "port": 80,
+        "namespace": "",
+        "service": "azure-imds",
+        "pod": "",
+        "container": "",
+        "nodeRole": "worker",
+        "optional": false
+    }
+]`
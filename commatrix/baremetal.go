@@ -0,0 +1,66 @@
+package commatrix
+
+func init() {
+	RegisterProvider(Baremetal, staticProvider{
+		name:   "baremetal",
+		master: baremetalStaticEntriesMaster,
+		worker: baremetalStaticEntriesWorker,
+	})
+}
+
+// baremetalStaticEntriesMaster covers the control-plane ports that only
+// exist on baremetal (no cloud load balancer in front of them): the
+// machine-config-server and the keepalived-managed API/ingress VIPs.
+const baremetalStaticEntriesMaster = `
+[
+    {
+        "direction": "Ingress",
+        "protocol": "TCP",
+        "port": 22623,
+        "namespace": "openshift-machine-config-operator",
+        "service": "machine-config-server",
+        "pod": "machine-config-server",
+        "container": "machine-config-server",
+        "nodeRole": "master",
+        "optional": false
+    },
+    {
+        "direction": "Ingress",
+        "protocol": "TCP",
+        "port": 22624,
+        "namespace": "openshift-machine-config-operator",
+        "service": "machine-config-server",
+        "pod": "machine-config-server",
+        "container": "machine-config-server",
+        "nodeRole": "master",
+        "optional": false
+    },
+    {
+        "direction": "Ingress",
+        "protocol": "VRRP",
+        "port": 0,
+        "namespace": "openshift-vip",
+        "service": "keepalived",
+        "pod": "keepalived",
+        "container": "keepalived",
+        "nodeRole": "master",
+        "optional": false
+    }
+]`
+
+// baremetalStaticEntriesWorker covers the worker-side half of the same VIP
+// failover traffic.
+const baremetalStaticEntriesWorker = `
+[
+    {
+        "direction": "Ingress",
+        "protocol": "VRRP",
+        "port": 0,
+        "namespace": "openshift-vip",
+        "service": "keepalived",
+        "pod": "keepalived",
+        "container": "keepalived",
+        "nodeRole": "worker",
+        "optional": false
+    }
+]`
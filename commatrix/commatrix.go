@@ -19,6 +19,8 @@ type Env int
 const (
 	Baremetal Env = iota
 	AWS
+	Azure
+	GCP
 )
 
 type Deployment int
@@ -53,7 +55,7 @@ func New(kubeconfigPath string, customEntriesPath string, e Env, d Deployment) (
 	}
 	res = append(res, epSliceComDetails...)
 
-	staticEntries, err := getStaticEntries(e, d)
+	staticEntries, err := getStaticEntries(cs, e, d)
 	if err != nil {
 		return nil, err
 	}
@@ -92,44 +94,40 @@ func addFromFile(fp string) ([]types.ComDetails, error) {
 	return res, nil
 }
 
-func getStaticEntries(e Env, d Deployment) ([]types.ComDetails, error) {
+// getStaticEntries collects the well-known entries for e: the
+// CloudProvider-specific entries registered for e (static and, where
+// supported, runtime-discovered), followed by the entries common to every
+// environment.
+func getStaticEntries(cs *client.ClientSet, e Env, d Deployment) ([]types.ComDetails, error) {
+	provider, err := providerFor(e)
+	if err != nil {
+		return nil, err
+	}
+
 	comDetails := []types.ComDetails{}
-	add := []types.ComDetails{}
 
-	switch e {
-	case Baremetal:
-		err := json.Unmarshal([]byte(baremetalStaticEntriesMaster), &add)
-		if err != nil {
-			return nil, fmt.Errorf("failed to unmarshal static entries: %v", err)
-		}
-		comDetails = append(comDetails, add...)
-		if d == SNO {
-			break
-		}
-		err = json.Unmarshal([]byte(baremetalStaticEntriesWorker), &add)
-		if err != nil {
-			return nil, fmt.Errorf("failed to unmarshal static entries: %v", err)
-		}
-		comDetails = append(comDetails, add...)
-	case AWS:
-		err := json.Unmarshal([]byte(awsCloudStaticEntriesMaster), &add)
-		if err != nil {
-			return nil, fmt.Errorf("failed to unmarshal static entries: %v", err)
-		}
-		comDetails = append(comDetails, add...)
-		if d == SNO {
-			break
-		}
-		err = json.Unmarshal([]byte(awsCloudStaticEntriesWorker), &add)
+	master, err := provider.MasterEntries(d)
+	if err != nil {
+		return nil, fmt.Errorf("failed getting %s master entries: %w", provider.Name(), err)
+	}
+	comDetails = append(comDetails, master...)
+
+	if d == MNO {
+		worker, err := provider.WorkerEntries(d)
 		if err != nil {
-			return nil, fmt.Errorf("failed to unmarshal static entries: %v", err)
+			return nil, fmt.Errorf("failed getting %s worker entries: %w", provider.Name(), err)
 		}
-		comDetails = append(comDetails, add...)
-	default:
-		return nil, fmt.Errorf("invalid value for cluster environment")
+		comDetails = append(comDetails, worker...)
 	}
 
-	err := json.Unmarshal([]byte(generalStaticEntriesMaster), &add)
+	dynamic, err := provider.DiscoverDynamic(cs)
+	if err != nil {
+		return nil, fmt.Errorf("failed discovering %s dynamic entries: %w", provider.Name(), err)
+	}
+	comDetails = append(comDetails, dynamic...)
+
+	add := []types.ComDetails{}
+	err = json.Unmarshal([]byte(generalStaticEntriesMaster), &add)
 	if err != nil {
 		return nil, fmt.Errorf("failed to unmarshal static entries: %v", err)
 	}
@@ -0,0 +1,53 @@
+package commatrix
+
+func init() {
+	RegisterProvider(GCP, staticProvider{
+		name:   "gcp",
+		master: gcpStaticEntriesMaster,
+		worker: gcpStaticEntriesWorker,
+	})
+}
+
+// gcpStaticEntriesMaster covers the GCP-specific flows master nodes need:
+// the metadata server (169.254.169.254) and the GCP cloud-controller-manager.
+const gcpStaticEntriesMaster = `
+[
+    {
+        "direction": "Egress",
+        "protocol": "TCP",
+        "port": 80,
+        "namespace": "",
+        "service": "gcp-metadata-server",
+        "pod": "",
+        "container": "",
+        "nodeRole": "master",
+        "optional": false
+    },
+    {
+        "direction": "Ingress",
+        "protocol": "TCP",
+        "port": 10258,
+        "namespace": "openshift-cloud-controller-manager",
+        "service": "gcp-cloud-controller-manager",
+        "pod": "gcp-cloud-controller-manager",
+        "container": "cloud-controller-manager",
+        "nodeRole": "master",
+        "optional": false
+    }
+]`
+
+// gcpStaticEntriesWorker covers the GCP-specific flows worker nodes need.
+const gcpStaticEntriesWorker = `
+[
+    {
+        "direction": "Egress",
+        "protocol": "TCP",
+        "port": 80,
+        "namespace": "",
+        "service": "gcp-metadata-server",
+        "pod": "",
+        "container": "",
+        "nodeRole": "worker",
+        "optional": false
+    }
+]`
@@ -0,0 +1,105 @@
+package commatrix
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/openshift-kni/commatrix/client"
+	"github.com/openshift-kni/commatrix/types"
+)
+
+// CloudProvider supplies the static, well-known ComDetails entries for a
+// specific infrastructure environment - the control-plane/etcd/SDN ports
+// that can't be discovered from EndpointSlices - plus any entries that can
+// only be found by querying that provider's own APIs at runtime.
+type CloudProvider interface {
+	// Name identifies the provider, e.g. for error messages.
+	Name() string
+
+	// MasterEntries returns the static entries required on master nodes.
+	MasterEntries(d Deployment) ([]types.ComDetails, error)
+
+	// WorkerEntries returns the static entries required on worker nodes.
+	// Implementations return an empty slice, not an error, when d is SNO.
+	WorkerEntries(d Deployment) ([]types.ComDetails, error)
+
+	// DiscoverDynamic returns entries that can only be found by querying
+	// the provider's APIs at runtime, e.g. IMDS/metadata-server endpoints
+	// that vary per deployment. Providers with nothing to discover return
+	// (nil, nil).
+	DiscoverDynamic(cs *client.ClientSet) ([]types.ComDetails, error)
+}
+
+// staticProvider implements CloudProvider for environments whose entries are
+// fixed JSON documents with no runtime discovery beyond what's already baked
+// into those documents - which today is every CloudProvider we have.
+type staticProvider struct {
+	name   string
+	master string
+	worker string
+}
+
+func (p staticProvider) Name() string {
+	return p.name
+}
+
+func (p staticProvider) MasterEntries(d Deployment) ([]types.ComDetails, error) {
+	var entries []types.ComDetails
+	if err := json.Unmarshal([]byte(p.master), &entries); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal static entries: %v", err)
+	}
+
+	return entries, nil
+}
+
+func (p staticProvider) WorkerEntries(d Deployment) ([]types.ComDetails, error) {
+	if d == SNO {
+		return nil, nil
+	}
+
+	var entries []types.ComDetails
+	if err := json.Unmarshal([]byte(p.worker), &entries); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal static entries: %v", err)
+	}
+
+	return entries, nil
+}
+
+// DiscoverDynamic has nothing to add: every current staticProvider's runtime
+// endpoints are already covered by its master/worker JSON documents.
+func (staticProvider) DiscoverDynamic(cs *client.ClientSet) ([]types.ComDetails, error) {
+	return nil, nil
+}
+
+var providers = map[Env]CloudProvider{}
+
+// RegisterProvider makes a CloudProvider available under e. Each provider
+// registers itself from its own init() function.
+func RegisterProvider(e Env, p CloudProvider) {
+	providers[e] = p
+}
+
+func providerFor(e Env) (CloudProvider, error) {
+	p, ok := providers[e]
+	if !ok {
+		return nil, fmt.Errorf("no CloudProvider registered for environment %d", e)
+	}
+
+	return p, nil
+}
+
+// EnvFromString parses a CLI-facing --env flag value into an Env.
+func EnvFromString(s string) (Env, error) {
+	switch s {
+	case "baremetal":
+		return Baremetal, nil
+	case "aws":
+		return AWS, nil
+	case "azure":
+		return Azure, nil
+	case "gcp":
+		return GCP, nil
+	default:
+		return 0, fmt.Errorf("invalid environment %q: must be one of baremetal, aws, azure, gcp", s)
+	}
+}
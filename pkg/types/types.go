@@ -6,6 +6,7 @@ import (
 	"encoding/csv"
 	"encoding/json"
 	"fmt"
+	"hash/fnv"
 	"path/filepath"
 	"reflect"
 	"slices"
@@ -14,10 +15,16 @@ import (
 	"github.com/gocarina/gocsv"
 
 	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	anpv1alpha1 "sigs.k8s.io/network-policy-api/apis/v1alpha1"
 	"sigs.k8s.io/yaml"
 
+	"github.com/openshift-kni/commatrix/client"
 	"github.com/openshift-kni/commatrix/pkg/consts"
 	"github.com/openshift-kni/commatrix/pkg/utils"
+	"github.com/openshift-kni/commatrix/pkg/verify"
 )
 
 type Env int
@@ -35,10 +42,11 @@ const (
 )
 
 const (
-	FormatJSON = "json"
-	FormatYAML = "yaml"
-	FormatCSV  = "csv"
-	FormatNFT  = "nft"
+	FormatJSON   = "json"
+	FormatYAML   = "yaml"
+	FormatCSV    = "csv"
+	FormatNFT    = "nft"
+	FormatNetPol = "netpol"
 )
 
 type ComMatrix struct {
@@ -131,15 +139,18 @@ func (m *ComMatrix) String() string {
 	return result.String()
 }
 
-func (m *ComMatrix) WriteMatrixToFileByType(utilsHelpers utils.UtilsInterface, fileNamePrefix, format string, deployment Deployment, destDir string) error {
-	if format == FormatNFT {
+// WriteMatrixToFileByType writes m to destDir in format. nodes is only
+// consulted for FormatNetPol, to resolve NodeRole peers to real node
+// addresses; pass nil for every other format.
+func (m *ComMatrix) WriteMatrixToFileByType(utilsHelpers utils.UtilsInterface, fileNamePrefix, format string, deployment Deployment, destDir string, nodes []corev1.Node) error {
+	if format == FormatNFT || format == FormatNetPol {
 		masterMatrix, workerMatrix := m.SeparateMatrixByRole()
-		err := masterMatrix.writeMatrixToFile(utilsHelpers, fileNamePrefix+"-master", format, destDir)
+		err := masterMatrix.writeMatrixToFile(utilsHelpers, fileNamePrefix+"-master", format, destDir, nodes)
 		if err != nil {
 			return err
 		}
 		if deployment == MNO {
-			err := workerMatrix.writeMatrixToFile(utilsHelpers, fileNamePrefix+"-worker", format, destDir)
+			err := workerMatrix.writeMatrixToFile(utilsHelpers, fileNamePrefix+"-worker", format, destDir, nodes)
 			if err != nil {
 				return err
 			}
@@ -147,7 +158,7 @@ func (m *ComMatrix) WriteMatrixToFileByType(utilsHelpers utils.UtilsInterface, f
 		return nil
 	}
 
-	err := m.writeMatrixToFile(utilsHelpers, fileNamePrefix, format, destDir)
+	err := m.writeMatrixToFile(utilsHelpers, fileNamePrefix, format, destDir, nodes)
 	if err != nil {
 		return err
 	}
@@ -232,7 +243,156 @@ func (m *ComMatrix) GenerateMatrixDiff(other *ComMatrix) (string, error) {
 	return diff, nil
 }
 
-func (m *ComMatrix) print(format string) ([]byte, error) {
+// Verify cross-checks m against the sockets actually listening on the
+// cluster's nodes, as reported by `ss`. It returns two matrices:
+// unexpectedOpen holds entries observed on a node but absent from m, and
+// missing holds entries declared in m that were never observed. Feed
+// either result to GenerateMatrixDiff to render it for humans.
+func (m *ComMatrix) Verify(cs *client.ClientSet) (unexpectedOpen ComMatrix, missing ComMatrix, err error) {
+	listeners, err := verify.DiscoverListeningSockets(cs)
+	if err != nil {
+		return ComMatrix{}, ComMatrix{}, fmt.Errorf("failed discovering listening sockets: %w", err)
+	}
+
+	observed := ComMatrix{}
+	for _, l := range listeners {
+		// rpc.statd binds to a random port on every boot; GenerateMatrixDiff
+		// already special-cases it out of diffs, so do the same here.
+		if l.Process == "rpc.statd" {
+			continue
+		}
+
+		role, err := GetNodeRole(l.Node)
+		if err != nil {
+			return ComMatrix{}, ComMatrix{}, err
+		}
+
+		cd := ComDetails{
+			Direction: "Ingress",
+			Protocol:  l.Protocol,
+			Port:      l.Port,
+			NodeRole:  role,
+			Service:   l.Process,
+		}
+
+		if l.PID != 0 {
+			info, err := containerInfoByPID(cs, l.Node.Name, l.PID)
+			if err == nil && len(info.Containers) > 0 {
+				cd.Namespace = info.Containers[0].Labels.PodNamespace
+				cd.Pod = info.Containers[0].Labels.PodName
+				cd.Container = info.Containers[0].Labels.ContainerName
+			}
+		}
+
+		observed.Matrix = append(observed.Matrix, cd)
+	}
+
+	for _, cd := range observed.Matrix {
+		if !m.Contains(cd) {
+			unexpectedOpen.Matrix = append(unexpectedOpen.Matrix, cd)
+		}
+	}
+
+	for _, cd := range m.Matrix {
+		if !observed.Contains(cd) {
+			missing.Matrix = append(missing.Matrix, cd)
+		}
+	}
+
+	unexpectedOpen.sort()
+	missing.sort()
+
+	return unexpectedOpen, missing, nil
+}
+
+// MatrixDiff is the machine-readable counterpart to GenerateMatrixDiff: the
+// same comparison, split into typed slices with a per-entry severity
+// classification instead of "+"/"-" prefixed lines.
+type MatrixDiff struct {
+	Added     []ComDetails      `json:"added" yaml:"added"`
+	Removed   []ComDetails      `json:"removed" yaml:"removed"`
+	Unchanged []ComDetails      `json:"unchanged" yaml:"unchanged"`
+	Severity  map[string]string `json:"severity" yaml:"severity"`
+}
+
+// wellKnownPorts additionally triggers a "warning" severity on addition,
+// for ports above 1024 that are nonetheless well-known cluster ports.
+var wellKnownPorts = map[int]bool{
+	6443:  true, // kube-apiserver
+	2379:  true, // etcd client
+	2380:  true, // etcd peer
+	10250: true, // kubelet
+	6641:  true, // ovnkube-db sbdb
+	6642:  true, // ovnkube-db nbdb
+}
+
+// GenerateStructuredDiff generates the diff between m and other as a typed
+// MatrixDiff instead of the "+"/"-" prefixed text GenerateMatrixDiff
+// produces, so it can be marshalled to JSON/YAML and gate automation (e.g.
+// a --diff-format={text,json,yaml} CLI flag that exits non-zero whenever
+// the result contains a "critical" entry). Removals of non-Optional
+// entries are classified "critical", additions on well-known or
+// privileged (<1024) ports are "warning", everything else is "info".
+func (m *ComMatrix) GenerateStructuredDiff(other *ComMatrix) (MatrixDiff, error) {
+	combinedComMatrix := m.combine(other)
+	mapComDetailToSign := m.markDiffBetweenMatrices(other)
+
+	diff := MatrixDiff{Severity: map[string]string{}}
+	for _, cd := range combinedComMatrix.Matrix {
+		switch mapComDetailToSign[cd.String()] {
+		case 1:
+			diff.Removed = append(diff.Removed, cd)
+			diff.Severity[cd.String()] = severityForRemoval(cd)
+		case -1:
+			diff.Added = append(diff.Added, cd)
+			diff.Severity[cd.String()] = severityForAddition(cd)
+		case 0:
+			diff.Unchanged = append(diff.Unchanged, cd)
+		}
+	}
+
+	return diff, nil
+}
+
+func severityForRemoval(cd ComDetails) string {
+	if !cd.Optional {
+		return "critical"
+	}
+
+	return "info"
+}
+
+func severityForAddition(cd ComDetails) string {
+	if cd.Port < 1024 || wellKnownPorts[cd.Port] {
+		return "warning"
+	}
+
+	return "info"
+}
+
+// ToJSON marshals d for a --diff-format=json CLI flag.
+func (d MatrixDiff) ToJSON() ([]byte, error) {
+	return json.MarshalIndent(d, "", "    ")
+}
+
+// ToYAML marshals d for a --diff-format=yaml CLI flag.
+func (d MatrixDiff) ToYAML() ([]byte, error) {
+	return yaml.Marshal(d)
+}
+
+// HasCritical reports whether d contains any "critical" severity entry, so
+// the CLI can exit non-zero and gate a PR merge on the result.
+func (d MatrixDiff) HasCritical() bool {
+	for _, severity := range d.Severity {
+		if severity == "critical" {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (m *ComMatrix) print(format string, nodes []corev1.Node) ([]byte, error) {
 	switch format {
 	case FormatJSON:
 		return m.ToJSON()
@@ -242,8 +402,10 @@ func (m *ComMatrix) print(format string) ([]byte, error) {
 		return m.ToYAML()
 	case FormatNFT:
 		return m.ToNFTables()
+	case FormatNetPol:
+		return m.ToNetworkPolicies(nodes)
 	default:
-		return nil, fmt.Errorf("invalid format: %s. Please specify json, csv, yaml, or nft", format)
+		return nil, fmt.Errorf("invalid format: %s. Please specify json, csv, yaml, nft, or netpol", format)
 	}
 }
 
@@ -260,8 +422,8 @@ func (m *ComMatrix) SeparateMatrixByRole() (ComMatrix, ComMatrix) {
 	return masterMatrix, workerMatrix
 }
 
-func (m *ComMatrix) writeMatrixToFile(utilsHelpers utils.UtilsInterface, fileName, format string, destDir string) error {
-	res, err := m.print(format)
+func (m *ComMatrix) writeMatrixToFile(utilsHelpers utils.UtilsInterface, fileName, format string, destDir string, nodes []corev1.Node) error {
+	res, err := m.print(format, nodes)
 	if err != nil {
 		return err
 	}
@@ -299,47 +461,314 @@ func (m *ComMatrix) Contains(cd ComDetails) bool {
 	return false
 }
 
+// ToNFTables renders m as an nft ruleset, one `table inet` per NodeRole.
+// Each table has a `<ROLE>_INPUT` chain (hook input) built from Ingress
+// entries and a `<ROLE>_OUTPUT` chain (hook output) built from Egress
+// entries, and every port rule is matched against both the `ip` and `ip6`
+// address families. Optional Ingress TCP entries are collected into a named
+// `optional_tcp_ports` set instead of being inlined, so enforcement can be
+// toggled off for all of them by clearing that one set; Optional Egress
+// entries are inlined in the output chain like any other. Every inlined port
+// rule carries a `meta mark` unique to its service, so matching traffic can
+// be picked out with `nft monitor trace`; the one rule matching the set
+// carries no mark.
 func (m *ComMatrix) ToNFTables() ([]byte, error) {
-	var tcpPorts []string
-	var udpPorts []string
-	for _, line := range m.Matrix {
-		if line.Protocol == "TCP" {
-			tcpPorts = append(tcpPorts, fmt.Sprint(line.Port))
-		} else if line.Protocol == "UDP" {
-			udpPorts = append(udpPorts, fmt.Sprint(line.Port))
+	roleOrder := []string{}
+	byRole := map[string][]ComDetails{}
+	for _, cd := range m.Matrix {
+		if _, ok := byRole[cd.NodeRole]; !ok {
+			roleOrder = append(roleOrder, cd.NodeRole)
 		}
+		byRole[cd.NodeRole] = append(byRole[cd.NodeRole], cd)
 	}
+	slices.Sort(roleOrder)
 
-	tcpPortsStr := strings.Join(tcpPorts, ", ")
-	udpPortsStr := strings.Join(udpPorts, ", ")
+	var out strings.Builder
+	out.WriteString("#!/usr/sbin/nft -f\n")
+	for _, role := range roleOrder {
+		out.WriteString("\n")
+		out.WriteString(nftTableForRole(role, byRole[role]))
+	}
 
-	result := fmt.Sprintf(`#!/usr/sbin/nft -f
+	return []byte(out.String()), nil
+}
 
-	table inet openshift_filter {
-		chain OPENSHIFT {
-			type filter hook input priority 1; policy accept;
+// nftTableForRole renders the `table inet` for a single NodeRole.
+func nftTableForRole(role string, entries []ComDetails) string {
+	var ingress, egress []ComDetails
+	var optionalTCP []string
+	for _, cd := range entries {
+		// nft's dport match only exists for tcp/udp/udplite/sctp/dccp; an
+		// entry like the baremetal provider's VRRP one has no port to match
+		// and would otherwise produce a ruleset nft refuses to load at all.
+		if cd.Protocol != "TCP" && cd.Protocol != "UDP" {
+			continue
+		}
 
-			# Allow loopback traffic
-			iif lo accept
-	
-			# Allow established and related traffic
-			ct state established,related accept
-	
-			# Allow ICMP on ipv4
-			ip protocol icmp accept
-			# Allow ICMP on ipv6
-			ip6 nexthdr ipv6-icmp accept
+		// The optional_tcp_ports set is only rendered into the INPUT chain
+		// below, so only Ingress (or legacy, direction-less) entries can be
+		// carved out into it without silently dropping Egress ones.
+		if cd.Optional && cd.Protocol == "TCP" && cd.Direction != "Egress" {
+			optionalTCP = append(optionalTCP, fmt.Sprint(cd.Port))
+			continue
+		}
 
-			# Allow specific TCP and UDP ports
-			tcp dport  { %s } accept
-			udp dport { %s } accept
+		if cd.Direction == "Egress" {
+			egress = append(egress, cd)
+		} else {
+			// Legacy entries with no Direction set are treated as Ingress.
+			ingress = append(ingress, cd)
+		}
+	}
 
-			# Logging and default drop
-			log prefix "firewall " drop
+	var b strings.Builder
+	fmt.Fprintf(&b, "table inet openshift_filter_%s {\n", role)
+
+	if len(optionalTCP) > 0 {
+		fmt.Fprintf(&b, "\t# Optional TCP ports: clear this set's elements to stop accepting them.\n")
+		fmt.Fprintf(&b, "\tset optional_tcp_ports {\n\t\ttype inet_service\n\t\telements = { %s }\n\t}\n\n", strings.Join(optionalTCP, ", "))
+	}
+
+	fmt.Fprintf(&b, "\tchain %s_INPUT {\n", strings.ToUpper(role))
+	b.WriteString("\t\ttype filter hook input priority 1; policy accept;\n\n")
+	b.WriteString("\t\t# Allow loopback traffic\n\t\tiif lo accept\n\n")
+	b.WriteString("\t\t# Allow established and related traffic\n\t\tct state established,related accept\n\n")
+	b.WriteString("\t\t# Allow ICMP on ipv4 and ipv6\n\t\tip protocol icmp accept\n\t\tip6 nexthdr ipv6-icmp accept\n\n")
+	b.WriteString(nftPortRules(ingress))
+	if len(optionalTCP) > 0 {
+		b.WriteString("\n\t\t# Optional TCP ports, toggled via the optional_tcp_ports set above\n\t\ttcp dport @optional_tcp_ports accept\n")
+	}
+	fmt.Fprintf(&b, "\n\t\tlog prefix \"firewall-%s-input \" drop\n\t}\n\n", role)
+
+	fmt.Fprintf(&b, "\tchain %s_OUTPUT {\n", strings.ToUpper(role))
+	b.WriteString("\t\ttype filter hook output priority 1; policy accept;\n\n")
+	b.WriteString(nftPortRules(egress))
+	fmt.Fprintf(&b, "\n\t\tlog prefix \"firewall-%s-output \" drop\n\t}\n", role)
+
+	b.WriteString("}\n")
+
+	return b.String()
+}
+
+// nftPortRules renders one `meta mark`-tagged accept rule per entry, against
+// both the `ip` and `ip6` address families.
+func nftPortRules(entries []ComDetails) string {
+	var b strings.Builder
+	for _, cd := range entries {
+		proto := strings.ToLower(cd.Protocol)
+		mark := markForService(cd.Service)
+		fmt.Fprintf(&b, "\t\t# %s\n", serviceLabel(cd))
+		fmt.Fprintf(&b, "\t\tip saddr 0.0.0.0/0 %s dport %d meta mark set 0x%04x accept\n", proto, cd.Port, mark)
+		fmt.Fprintf(&b, "\t\tip6 saddr ::/0 %s dport %d meta mark set 0x%04x accept\n", proto, cd.Port, mark)
+	}
+
+	return b.String()
+}
+
+// markForService derives a stable, service-specific nft mark so matching
+// traffic can be singled out with `nft monitor trace`.
+func markForService(service string) uint32 {
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(service))
+
+	return h.Sum32() & 0xffff
+}
+
+func serviceLabel(cd ComDetails) string {
+	if cd.Service == "" {
+		return fmt.Sprintf("%s/%d", cd.Protocol, cd.Port)
+	}
+
+	return cd.Service
+}
+
+// ToNetworkPolicies renders m as a multi-document YAML stream of
+// networking.k8s.io/v1 NetworkPolicy resources, one per Namespace/Pod pair,
+// plus a single cluster-scoped AdminNetworkPolicy baseline for entries that
+// have no Namespace (static host-level entries such as the kubelet or CNI
+// ports). Each policy has a single ingress rule with one port entry per
+// entry, and its peers are the nodes of every NodeRole among those entries:
+// since neither NetworkPolicy nor AdminNetworkPolicy has
+// a "peer is a node with role X" concept, nodes is used to resolve each role
+// to the InternalIPs of the nodes that actually carry it, and those become
+// per-node /32 IPBlock peers.
+func (m *ComMatrix) ToNetworkPolicies(nodes []corev1.Node) ([]byte, error) {
+	type podKey struct {
+		Namespace string
+		Pod       string
+	}
+
+	grouped := map[podKey][]ComDetails{}
+	clusterScoped := []ComDetails{}
+	for _, cd := range m.Matrix {
+		if cd.Namespace == "" {
+			clusterScoped = append(clusterScoped, cd)
+			continue
+		}
+		k := podKey{cd.Namespace, cd.Pod}
+		grouped[k] = append(grouped[k], cd)
+	}
+
+	keys := make([]podKey, 0, len(grouped))
+	for k := range grouped {
+		keys = append(keys, k)
+	}
+	slices.SortFunc(keys, func(a, b podKey) int {
+		if res := cmp.Compare(a.Namespace, b.Namespace); res != 0 {
+			return res
 		}
-	}`, tcpPortsStr, udpPortsStr)
+		return cmp.Compare(a.Pod, b.Pod)
+	})
+
+	cidrsByRole := nodeCIDRsByRole(nodes)
 
-	return []byte(result), nil
+	docs := make([][]byte, 0, len(keys)+1)
+	for _, k := range keys {
+		policy := networkPolicyFor(k.Namespace, k.Pod, grouped[k], cidrsByRole)
+		out, err := yaml.Marshal(policy)
+		if err != nil {
+			return nil, fmt.Errorf("failed marshalling NetworkPolicy for %s/%s: %w", k.Namespace, k.Pod, err)
+		}
+		docs = append(docs, out)
+	}
+
+	if len(clusterScoped) > 0 {
+		out, err := yaml.Marshal(adminNetworkPolicyFor(clusterScoped, cidrsByRole))
+		if err != nil {
+			return nil, fmt.Errorf("failed marshalling AdminNetworkPolicy: %w", err)
+		}
+		docs = append(docs, out)
+	}
+
+	return bytes.Join(docs, []byte("---\n")), nil
+}
+
+// nodeCIDRsByRole maps each NodeRole to the /32 CIDR of every node's
+// InternalIP that carries it, using the same role resolution GetNodeRole
+// applies elsewhere.
+func nodeCIDRsByRole(nodes []corev1.Node) map[string][]string {
+	res := map[string][]string{}
+	for i := range nodes {
+		node := &nodes[i]
+
+		role, err := GetNodeRole(node)
+		if err != nil {
+			continue
+		}
+
+		for _, addr := range node.Status.Addresses {
+			if addr.Type != corev1.NodeInternalIP {
+				continue
+			}
+			res[role] = append(res[role], fmt.Sprintf("%s/32", addr.Address))
+		}
+	}
+
+	return res
+}
+
+// networkPolicyFor builds an ingress-only NetworkPolicy selecting the given
+// pod, with one port entry per (Protocol, Port) pair and IPBlock peers built
+// from the real node addresses of the distinct NodeRoles that need access.
+func networkPolicyFor(namespace, pod string, entries []ComDetails, cidrsByRole map[string][]string) *networkingv1.NetworkPolicy {
+	ports := make([]networkingv1.NetworkPolicyPort, 0, len(entries))
+	roles := map[string]bool{}
+	for _, cd := range entries {
+		proto := corev1.Protocol(strings.ToUpper(cd.Protocol))
+		port := intstr.FromInt(cd.Port)
+		ports = append(ports, networkingv1.NetworkPolicyPort{
+			Protocol: &proto,
+			Port:     &port,
+		})
+		roles[cd.NodeRole] = true
+	}
+
+	peers := make([]networkingv1.NetworkPolicyPeer, 0)
+	for role := range roles {
+		for _, cidr := range cidrsByRole[role] {
+			peers = append(peers, networkingv1.NetworkPolicyPeer{
+				IPBlock: &networkingv1.IPBlock{CIDR: cidr},
+			})
+		}
+	}
+
+	return &networkingv1.NetworkPolicy{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "networking.k8s.io/v1",
+			Kind:       "NetworkPolicy",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      fmt.Sprintf("commatrix-%s", pod),
+			Namespace: namespace,
+		},
+		Spec: networkingv1.NetworkPolicySpec{
+			PodSelector: metav1.LabelSelector{
+				MatchLabels: map[string]string{"app": pod},
+			},
+			PolicyTypes: []networkingv1.PolicyType{networkingv1.PolicyTypeIngress},
+			Ingress: []networkingv1.NetworkPolicyIngressRule{
+				{
+					Ports: ports,
+					From:  peers,
+				},
+			},
+		},
+	}
+}
+
+// adminNetworkPolicyFor builds a single cluster-scoped AdminNetworkPolicy
+// baseline covering entries that have no owning pod (static host-level
+// flows like the kubelet or CNI), peered by the real node addresses of the
+// NodeRoles that need access.
+func adminNetworkPolicyFor(entries []ComDetails, cidrsByRole map[string][]string) *anpv1alpha1.AdminNetworkPolicy {
+	ports := make([]anpv1alpha1.AdminNetworkPolicyPort, 0, len(entries))
+	roles := map[string]bool{}
+	for _, cd := range entries {
+		proto := corev1.Protocol(strings.ToUpper(cd.Protocol))
+		port := int32(cd.Port)
+		ports = append(ports, anpv1alpha1.AdminNetworkPolicyPort{
+			PortNumber: &anpv1alpha1.Port{
+				Protocol: proto,
+				Port:     port,
+			},
+		})
+		roles[cd.NodeRole] = true
+	}
+
+	subjects := make([]anpv1alpha1.AdminNetworkPolicyIngressPeer, 0)
+	for role := range roles {
+		cidrs := make([]anpv1alpha1.CIDR, 0, len(cidrsByRole[role]))
+		for _, cidr := range cidrsByRole[role] {
+			cidrs = append(cidrs, anpv1alpha1.CIDR(cidr))
+		}
+		subjects = append(subjects, anpv1alpha1.AdminNetworkPolicyIngressPeer{
+			Networks: cidrs,
+		})
+	}
+
+	return &anpv1alpha1.AdminNetworkPolicy{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "policy.networking.k8s.io/v1alpha1",
+			Kind:       "AdminNetworkPolicy",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "commatrix-baseline",
+		},
+		Spec: anpv1alpha1.AdminNetworkPolicySpec{
+			Priority: 10,
+			Subject: anpv1alpha1.AdminNetworkPolicySubject{
+				Namespaces: &metav1.LabelSelector{},
+			},
+			Ingress: []anpv1alpha1.AdminNetworkPolicyIngressRule{
+				{
+					Name:   "commatrix-host-ports",
+					Action: anpv1alpha1.AdminNetworkPolicyRuleActionAllow,
+					From:   subjects,
+					Ports:  &ports,
+				},
+			},
+		},
+	}
 }
 
 func (m *ComMatrix) deleteDuplicates() {
@@ -425,3 +854,26 @@ func GetNodeRole(node *corev1.Node) (string, error) {
 
 	return "", fmt.Errorf("unable to determine role for node %s", node.Name)
 }
+
+// containerInfoByPID maps a pid observed on a node back to the pod/container
+// that owns it, by reading /proc/<pid>/cgroup inside a debug pod and
+// looking up the container ID it contains via `crictl ps`, whose labels name
+// the pod and container. Kernel listeners (NFS) have no owning container and
+// are left blank by the caller, since they never reach this function with a
+// non-zero pid.
+func containerInfoByPID(cs *client.ClientSet, nodeName string, pid int) (*ContainerInfo, error) {
+	cmd := fmt.Sprintf(
+		`crictl ps -o json --id "$(grep -o 'crio-[0-9a-f]\+' /proc/%d/cgroup | head -1 | sed 's/crio-//')"`, pid)
+
+	out, err := client.ExecuteCommandOnNode(cs, nodeName, cmd)
+	if err != nil {
+		return nil, fmt.Errorf("failed resolving container for pid %d on node %s: %w", pid, nodeName, err)
+	}
+
+	info := &ContainerInfo{}
+	if err := json.Unmarshal([]byte(out), info); err != nil {
+		return nil, fmt.Errorf("failed unmarshalling container info for pid %d on node %s: %w", pid, nodeName, err)
+	}
+
+	return info, nil
+}
@@ -0,0 +1,180 @@
+package types
+
+import (
+	"strings"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/openshift-kni/commatrix/pkg/consts"
+)
+
+func nodeWithRole(name, role string) corev1.Node {
+	node := corev1.Node{}
+	node.Name = name
+	node.Labels = map[string]string{consts.RoleLabel + role: ""}
+	return node
+}
+
+func TestToNFTablesSkipsNonTCPUDPProtocols(t *testing.T) {
+	m := &ComMatrix{Matrix: []ComDetails{
+		{Direction: "Ingress", Protocol: "TCP", Port: 6443, NodeRole: "master"},
+		{Direction: "Ingress", Protocol: "VRRP", Port: 0, NodeRole: "master"},
+		{Direction: "Egress", Protocol: "VRRP", Port: 0, NodeRole: "worker"},
+	}}
+
+	out, err := m.ToNFTables()
+	if err != nil {
+		t.Fatalf("ToNFTables() returned error: %v", err)
+	}
+
+	result := string(out)
+	if strings.Contains(result, "vrrp") {
+		t.Errorf("ToNFTables() rendered a vrrp dport rule, which nft cannot parse:\n%s", result)
+	}
+	if !strings.Contains(result, "tcp dport 6443") {
+		t.Errorf("ToNFTables() dropped the valid TCP entry, got:\n%s", result)
+	}
+}
+
+func TestNftTableForRoleKeepsEgressOptionalTCP(t *testing.T) {
+	entries := []ComDetails{
+		{Direction: "Egress", Protocol: "TCP", Port: 9999, NodeRole: "master", Optional: true},
+	}
+
+	table := nftTableForRole("master", entries)
+
+	if !strings.Contains(table, "tcp dport 9999") {
+		t.Errorf("nftTableForRole dropped an Egress optional TCP entry entirely, got:\n%s", table)
+	}
+}
+
+func TestMarkForServiceIsStableAndDistinct(t *testing.T) {
+	a1 := markForService("kube-apiserver")
+	a2 := markForService("kube-apiserver")
+	b := markForService("etcd")
+
+	if a1 != a2 {
+		t.Errorf("markForService is not stable: got %d and %d for the same input", a1, a2)
+	}
+	if a1 == b {
+		t.Errorf("markForService produced the same mark for different services: %d", a1)
+	}
+}
+
+func TestNodeCIDRsByRole(t *testing.T) {
+	master := nodeWithRole("master-0", "master")
+	master.Status.Addresses = []corev1.NodeAddress{
+		{Type: corev1.NodeInternalIP, Address: "10.0.0.1"},
+		{Type: corev1.NodeExternalIP, Address: "203.0.113.1"},
+	}
+	worker := nodeWithRole("worker-0", "worker")
+	worker.Status.Addresses = []corev1.NodeAddress{
+		{Type: corev1.NodeInternalIP, Address: "10.0.0.2"},
+	}
+
+	cidrs := nodeCIDRsByRole([]corev1.Node{master, worker})
+
+	if got := cidrs["master"]; len(got) != 1 || got[0] != "10.0.0.1/32" {
+		t.Errorf("nodeCIDRsByRole()[master] = %v, want [10.0.0.1/32]", got)
+	}
+	if got := cidrs["worker"]; len(got) != 1 || got[0] != "10.0.0.2/32" {
+		t.Errorf("nodeCIDRsByRole()[worker] = %v, want [10.0.0.2/32]", got)
+	}
+}
+
+func TestNetworkPolicyForPeersByNodeCIDR(t *testing.T) {
+	entries := []ComDetails{
+		{Protocol: "TCP", Port: 8080, NodeRole: "master"},
+	}
+	cidrsByRole := map[string][]string{"master": {"10.0.0.1/32"}}
+
+	policy := networkPolicyFor("openshift-foo", "foo", entries, cidrsByRole)
+
+	if len(policy.Spec.Ingress) != 1 || len(policy.Spec.Ingress[0].From) != 1 {
+		t.Fatalf("unexpected ingress peers: %+v", policy.Spec.Ingress)
+	}
+
+	peer := policy.Spec.Ingress[0].From[0]
+	if peer.IPBlock == nil || peer.IPBlock.CIDR != "10.0.0.1/32" {
+		t.Errorf("NetworkPolicy peer = %+v, want an IPBlock for 10.0.0.1/32", peer)
+	}
+	if peer.PodSelector != nil {
+		t.Errorf("NetworkPolicy peer still selects by PodSelector: %+v", peer)
+	}
+}
+
+func TestAdminNetworkPolicyForPeersByNodeCIDR(t *testing.T) {
+	entries := []ComDetails{
+		{Protocol: "TCP", Port: 9979, NodeRole: "master"},
+	}
+	cidrsByRole := map[string][]string{"master": {"10.0.0.1/32"}}
+
+	anp := adminNetworkPolicyFor(entries, cidrsByRole)
+
+	if len(anp.Spec.Ingress) != 1 || len(anp.Spec.Ingress[0].From) != 1 {
+		t.Fatalf("unexpected ingress peers: %+v", anp.Spec.Ingress)
+	}
+
+	networks := anp.Spec.Ingress[0].From[0].Networks
+	if len(networks) != 1 || string(networks[0]) != "10.0.0.1/32" {
+		t.Errorf("AdminNetworkPolicy peer networks = %v, want [10.0.0.1/32]", networks)
+	}
+}
+
+func TestGenerateStructuredDiff(t *testing.T) {
+	m := &ComMatrix{Matrix: []ComDetails{
+		{Direction: "Ingress", Protocol: "TCP", Port: 6443, NodeRole: "master"},
+		{Direction: "Ingress", Protocol: "TCP", Port: 12345, NodeRole: "master", Optional: false},
+	}}
+	other := &ComMatrix{Matrix: []ComDetails{
+		{Direction: "Ingress", Protocol: "TCP", Port: 6443, NodeRole: "master"},
+		{Direction: "Ingress", Protocol: "TCP", Port: 443, NodeRole: "master"},
+	}}
+
+	diff, err := m.GenerateStructuredDiff(other)
+	if err != nil {
+		t.Fatalf("GenerateStructuredDiff() returned error: %v", err)
+	}
+
+	if len(diff.Unchanged) != 1 || diff.Unchanged[0].Port != 6443 {
+		t.Errorf("Unchanged = %+v, want the 6443 entry", diff.Unchanged)
+	}
+	if len(diff.Removed) != 1 || diff.Removed[0].Port != 12345 {
+		t.Errorf("Removed = %+v, want the 12345 entry", diff.Removed)
+	}
+	if len(diff.Added) != 1 || diff.Added[0].Port != 443 {
+		t.Errorf("Added = %+v, want the 443 entry", diff.Added)
+	}
+
+	if got := diff.Severity[diff.Removed[0].String()]; got != "critical" {
+		t.Errorf("severity of removing a non-Optional entry = %q, want %q", got, "critical")
+	}
+	if got := diff.Severity[diff.Added[0].String()]; got != "warning" {
+		t.Errorf("severity of adding a privileged port = %q, want %q", got, "warning")
+	}
+	if !diff.HasCritical() {
+		t.Error("HasCritical() = false, want true: a non-Optional entry was removed")
+	}
+}
+
+func TestSeverityForRemoval(t *testing.T) {
+	if got := severityForRemoval(ComDetails{Optional: false}); got != "critical" {
+		t.Errorf("severityForRemoval(non-optional) = %q, want %q", got, "critical")
+	}
+	if got := severityForRemoval(ComDetails{Optional: true}); got != "info" {
+		t.Errorf("severityForRemoval(optional) = %q, want %q", got, "info")
+	}
+}
+
+func TestSeverityForAddition(t *testing.T) {
+	if got := severityForAddition(ComDetails{Port: 80}); got != "warning" {
+		t.Errorf("severityForAddition(privileged port) = %q, want %q", got, "warning")
+	}
+	if got := severityForAddition(ComDetails{Port: 6443}); got != "warning" {
+		t.Errorf("severityForAddition(well-known port) = %q, want %q", got, "warning")
+	}
+	if got := severityForAddition(ComDetails{Port: 30500}); got != "info" {
+		t.Errorf("severityForAddition(random high port) = %q, want %q", got, "info")
+	}
+}
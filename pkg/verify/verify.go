@@ -0,0 +1,131 @@
+// Package verify discovers the sockets that are actually listening on a
+// cluster's nodes, so a generated ComMatrix can be checked against reality
+// instead of only being generated once and trusted forever.
+package verify
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/openshift-kni/commatrix/client"
+)
+
+const (
+	tcpListCmd = "ss -anptlH"
+	udpListCmd = "ss -anpulH"
+)
+
+// Listener is a single socket observed listening on a node, as reported by ss.
+type Listener struct {
+	Node     *corev1.Node
+	Protocol string // "TCP" or "UDP"
+	Port     int
+	PID      int    // 0 for kernel-owned sockets (e.g. NFS) that have no process
+	Process  string // process name as reported by ss; empty when PID is 0
+}
+
+// DiscoverListeningSockets execs into a debug pod on every node in cs and
+// parses the output of `ss -anptlH`/`ss -anpulH` into a flat list of
+// Listeners. A failure talking to a single node aborts the whole call,
+// since a partial picture is worse than an explicit error.
+func DiscoverListeningSockets(cs *client.ClientSet) ([]Listener, error) {
+	nodes, err := cs.CoreV1().Nodes().List(context.TODO(), metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed listing nodes: %w", err)
+	}
+
+	res := make([]Listener, 0)
+	for i := range nodes.Items {
+		node := &nodes.Items[i]
+
+		tcpOut, err := client.ExecuteCommandOnNode(cs, node.Name, tcpListCmd)
+		if err != nil {
+			return nil, fmt.Errorf("failed running %q on node %s: %w", tcpListCmd, node.Name, err)
+		}
+		res = append(res, parseSSOutput(node, "TCP", tcpOut)...)
+
+		udpOut, err := client.ExecuteCommandOnNode(cs, node.Name, udpListCmd)
+		if err != nil {
+			return nil, fmt.Errorf("failed running %q on node %s: %w", udpListCmd, node.Name, err)
+		}
+		res = append(res, parseSSOutput(node, "UDP", udpOut)...)
+	}
+
+	return res, nil
+}
+
+// parseSSOutput turns the lines of `ss -H` output into Listeners. Lines we
+// can't make sense of (headers, malformed addresses) are skipped rather
+// than failing the whole node.
+func parseSSOutput(node *corev1.Node, protocol, out string) []Listener {
+	res := make([]Listener, 0)
+
+	for _, line := range strings.Split(strings.TrimSpace(out), "\n") {
+		if line == "" {
+			continue
+		}
+
+		fields := strings.Fields(line)
+		if len(fields) < 5 {
+			continue
+		}
+
+		// State Recv-Q Send-Q Local-Address:Port Peer-Address:Port [users:(...)]
+		port, err := portFromAddr(fields[3])
+		if err != nil {
+			continue
+		}
+
+		pid, process := pidAndProcess(fields)
+		res = append(res, Listener{
+			Node:     node,
+			Protocol: protocol,
+			Port:     port,
+			PID:      pid,
+			Process:  process,
+		})
+	}
+
+	return res
+}
+
+func portFromAddr(addr string) (int, error) {
+	idx := strings.LastIndex(addr, ":")
+	if idx == -1 {
+		return 0, fmt.Errorf("no port in address %q", addr)
+	}
+
+	return strconv.Atoi(addr[idx+1:])
+}
+
+// pidAndProcess extracts the pid and process name from the trailing
+// `users:(("name",pid=1234,fd=5))` column ss prints. Kernel listeners, like
+// NFS, have no users column at all, so they return (0, "").
+func pidAndProcess(fields []string) (int, string) {
+	last := fields[len(fields)-1]
+	if !strings.HasPrefix(last, "users:") {
+		return 0, ""
+	}
+
+	// users:(("rpc.statd",pid=1234,fd=6))
+	inner := strings.TrimPrefix(last, "users:((")
+	parts := strings.Split(inner, ",")
+	if len(parts) < 2 {
+		return 0, ""
+	}
+
+	name := strings.Trim(parts[0], `"`)
+
+	pidPart := strings.TrimPrefix(parts[1], "pid=")
+	pid, err := strconv.Atoi(pidPart)
+	if err != nil {
+		return 0, name
+	}
+
+	return pid, name
+}
@@ -0,0 +1,105 @@
+package verify
+
+import (
+	"strings"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestPortFromAddr(t *testing.T) {
+	tests := []struct {
+		name    string
+		addr    string
+		want    int
+		wantErr bool
+	}{
+		{name: "ipv4", addr: "0.0.0.0:22", want: 22},
+		{name: "ipv4 loopback", addr: "127.0.0.1:10257", want: 10257},
+		{name: "ipv6", addr: "[::]:6443", want: 6443},
+		{name: "wildcard host, no colon before port", addr: "*:111", want: 111},
+		{name: "no port", addr: "0.0.0.0", wantErr: true},
+		{name: "wildcard port", addr: "0.0.0.0:*", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := portFromAddr(tt.addr)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("portFromAddr(%q) = %d, want error", tt.addr, got)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("portFromAddr(%q) returned unexpected error: %v", tt.addr, err)
+			}
+			if got != tt.want {
+				t.Errorf("portFromAddr(%q) = %d, want %d", tt.addr, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPidAndProcess(t *testing.T) {
+	tests := []struct {
+		name        string
+		line        string
+		wantPID     int
+		wantProcess string
+	}{
+		{
+			name:        "process-owned socket",
+			line:        `LISTEN 0 128 0.0.0.0:22 0.0.0.0:* users:(("sshd",pid=1234,fd=3))`,
+			wantPID:     1234,
+			wantProcess: "sshd",
+		},
+		{
+			name:        "kernel-owned socket has no users column",
+			line:        `LISTEN 0 64 *:2049 *:*`,
+			wantPID:     0,
+			wantProcess: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pid, process := pidAndProcess(strings.Fields(tt.line))
+			if pid != tt.wantPID || process != tt.wantProcess {
+				t.Errorf("pidAndProcess(%q) = (%d, %q), want (%d, %q)", tt.line, pid, process, tt.wantPID, tt.wantProcess)
+			}
+		})
+	}
+}
+
+func TestParseSSOutput(t *testing.T) {
+	node := &corev1.Node{}
+	node.Name = "master-0"
+
+	out := `LISTEN 0 128       0.0.0.0:22        0.0.0.0:* users:(("sshd",pid=1234,fd=3))
+LISTEN 0 4096    127.0.0.1:10257      0.0.0.0:* users:(("kube-apiserver",pid=5678,fd=7))
+LISTEN 0 64              *:111             *:* users:(("rpcbind",pid=1,fd=8))
+LISTEN 0 64              *:2049            *:*
+`
+
+	listeners := parseSSOutput(node, "TCP", out)
+
+	want := []Listener{
+		{Node: node, Protocol: "TCP", Port: 22, PID: 1234, Process: "sshd"},
+		{Node: node, Protocol: "TCP", Port: 10257, PID: 5678, Process: "kube-apiserver"},
+		{Node: node, Protocol: "TCP", Port: 111, PID: 1, Process: "rpcbind"},
+		{Node: node, Protocol: "TCP", Port: 2049, PID: 0, Process: ""},
+	}
+
+	if len(listeners) != len(want) {
+		t.Fatalf("parseSSOutput returned %d listeners, want %d: %+v", len(listeners), len(want), listeners)
+	}
+
+	for i, got := range listeners {
+		w := want[i]
+		if got.Protocol != w.Protocol || got.Port != w.Port || got.PID != w.PID || got.Process != w.Process {
+			t.Errorf("listener %d = %+v, want %+v", i, got, w)
+		}
+	}
+}